@@ -41,11 +41,16 @@ func (s *Server) Aggregate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	warnings, ok := s.enforcePolicy(ctx, w, "aggregate", req.Database, req.Collection, req.Pipeline, nil)
+	if !ok {
+		return
+	}
+
 	msg, err := prepareRequest(
 		"aggregate", req.Collection,
 		"$db", req.Database,
 		"pipeline", req.Pipeline,
-		"cursor", wirebson.MustDocument(),
+		"cursor", cursorOptionsDocument(req.Cursor),
 	)
 	if err != nil {
 		http.Error(w, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
@@ -64,8 +69,11 @@ func (s *Server) Aggregate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	cursor := resp.Document().Get("cursor").(wirebson.AnyDocument)
-	firstBatch := must.NotFail(cursor.Decode()).Get("firstBatch").(wirebson.AnyArray)
-	s.writeJSONResponse(ctx, w, wirebson.MustDocument(
-		"documents", firstBatch,
-	))
+
+	if wantsNDJSON(r) {
+		s.streamCursor(ctx, w, req.Database, req.Collection, cursor, "firstBatch", warnings)
+		return
+	}
+
+	s.writeJSONResponse(ctx, w, cursorResponse(cursor, "firstBatch", warnings))
 }
@@ -0,0 +1,171 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
+	"github.com/FerretDB/FerretDB/v2/internal/util/lazyerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// ndjsonContentType is the content type requested by callers that want Aggregate and Find
+// to stream results instead of returning a single buffered JSON batch.
+const ndjsonContentType = "application/x-ndjson"
+
+// wantsNDJSON reports whether r asked for a streaming NDJSON response.
+func wantsNDJSON(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			if strings.TrimSpace(strings.SplitN(part, ";", 2)[0]) == ndjsonContentType {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// cursorIDsArray builds the `cursors` array of a killCursors command from the
+// caller-supplied cursor ids, which arrive as decimal strings (see [cursorResponse])
+// rather than bare JSON numbers so that 64-bit ids survive the round trip intact.
+func cursorIDsArray(ids []string) (*wirebson.Array, error) {
+	cursors := wirebson.MustArray()
+
+	for _, id := range ids {
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, lazyerrors.Error(err)
+		}
+
+		cursors.Add(n)
+	}
+
+	return cursors, nil
+}
+
+// cursorOptionsDocument builds the `cursor` option document for aggregate and find commands
+// from the caller-supplied [api.CursorOptions].
+func cursorOptionsDocument(opts *api.CursorOptions) *wirebson.Document {
+	if opts == nil || opts.BatchSize == nil {
+		return wirebson.MustDocument()
+	}
+
+	return wirebson.MustDocument("batchSize", *opts.BatchSize)
+}
+
+// cursorResponse builds the JSON response document for aggregate, find, and getMore,
+// extracting the batch of documents under batchField ("firstBatch" or "nextBatch")
+// together with the cursor id and namespace. Any policy warnings are attached as
+// a top-level "warnings" array.
+//
+// The cursor id is encoded as a decimal string rather than a bare JSON number: JSON
+// numbers are parsed as float64 by JavaScript, the primary consumer of this REST API,
+// which would silently corrupt ids above 2^53 and break the follow-up getMore/killCursors.
+func cursorResponse(cursor wirebson.AnyDocument, batchField string, warnings []string) *wirebson.Document {
+	doc := must.NotFail(cursor.Decode())
+
+	batch := doc.Get(batchField).(wirebson.AnyArray)
+
+	fields := []any{
+		"documents", batch,
+		"cursor", wirebson.MustDocument(
+			"id", strconv.FormatInt(doc.Get("id").(int64), 10),
+			"ns", doc.Get("ns"),
+		),
+	}
+
+	if len(warnings) > 0 {
+		fields = append(fields, "warnings", warnings)
+	}
+
+	return wirebson.MustDocument(fields...)
+}
+
+// streamCursor writes the documents of cursor, and of every subsequent getMore batch,
+// to w as newline-delimited JSON, flushing after each batch. It stops once the cursor
+// is exhausted (id 0) or a getMore call fails.
+//
+// Streaming does not have a natural place in the body for out-of-band data, so any
+// policy warnings are attached as an X-Warnings response header (JSON array) instead
+// of the "warnings" field used by the buffered JSON responses.
+func (s *Server) streamCursor(ctx context.Context, w http.ResponseWriter, database, collection string, cursor wirebson.AnyDocument, batchField string, warnings []string) {
+	if len(warnings) > 0 {
+		if b, err := json.Marshal(warnings); err == nil {
+			w.Header().Set("X-Warnings", string(b))
+		} else {
+			s.l.ErrorContext(ctx, "Failed to marshal NDJSON warnings header", slog.Any("error", err))
+		}
+	}
+
+	w.Header().Set("Content-Type", ndjsonContentType)
+
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+
+	for {
+		doc := must.NotFail(cursor.Decode())
+
+		batch := must.NotFail(doc.Get(batchField).(wirebson.AnyArray).Decode())
+
+		for i := 0; i < batch.Len(); i++ {
+			if err := enc.Encode(batch.Get(i)); err != nil {
+				s.l.ErrorContext(ctx, "Failed to write NDJSON document", slog.Any("error", err))
+				return
+			}
+		}
+
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		cursorID, _ := doc.Get("id").(int64)
+		if cursorID == 0 {
+			return
+		}
+
+		msg, err := prepareRequest(
+			"getMore", cursorID,
+			"$db", database,
+			"collection", collection,
+		)
+		if err != nil {
+			s.l.ErrorContext(ctx, "Failed to build getMore request", slog.Any("error", err))
+			return
+		}
+
+		resp, err := s.handler.Handle(ctx, msg)
+		if err != nil {
+			s.l.ErrorContext(ctx, "getMore request failed", slog.Any("error", err))
+			return
+		}
+
+		if !resp.OK() {
+			s.l.ErrorContext(ctx, "getMore request returned an error", slog.Any("response", resp.Document()))
+			return
+		}
+
+		cursor = resp.Document().Get("cursor").(wirebson.AnyDocument)
+		batchField = "nextBatch"
+	}
+}
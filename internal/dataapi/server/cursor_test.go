@@ -0,0 +1,133 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
+)
+
+func TestCursorOptionsDocument(t *testing.T) {
+	t.Parallel()
+
+	batchSize := int32(50)
+
+	testCases := map[string]struct {
+		opts     *api.CursorOptions
+		expected any
+	}{
+		"Nil":           {opts: nil, expected: nil},
+		"EmptyOptions":  {opts: &api.CursorOptions{}, expected: nil},
+		"WithBatchSize": {opts: &api.CursorOptions{BatchSize: &batchSize}, expected: batchSize},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			doc := cursorOptionsDocument(tc.opts)
+			assert.Equal(t, tc.expected, doc.Get("batchSize"))
+		})
+	}
+}
+
+func TestCursorIDsArray(t *testing.T) {
+	t.Parallel()
+
+	ids := []string{"1", "2", "3"}
+	arr, err := cursorIDsArray(ids)
+	require.NoError(t, err)
+
+	assert.Equal(t, len(ids), arr.Len())
+	for i, id := range ids {
+		assert.Equal(t, id, strconv.FormatInt(arr.Get(i).(int64), 10))
+	}
+}
+
+func TestCursorIDsArrayInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := cursorIDsArray([]string{"1", "not-a-number"})
+	assert.Error(t, err)
+}
+
+func TestCursorResponseFirstBatch(t *testing.T) {
+	t.Parallel()
+
+	cursor := wirebson.MustDocument(
+		"firstBatch", wirebson.MustArray(wirebson.MustDocument("v", int32(1))),
+		"id", int64(123),
+		"ns", "testdb.testcoll",
+	)
+
+	resp := cursorResponse(cursor, "firstBatch", nil)
+
+	assert.Equal(t, wirebson.MustDocument("v", int32(1)), resp.Get("documents").(*wirebson.Array).Get(0))
+
+	respCursor := resp.Get("cursor").(*wirebson.Document)
+	assert.Equal(t, "123", respCursor.Get("id"))
+	assert.Equal(t, "testdb.testcoll", respCursor.Get("ns"))
+	assert.Nil(t, resp.Get("warnings"))
+}
+
+func TestCursorResponseWarnings(t *testing.T) {
+	t.Parallel()
+
+	cursor := wirebson.MustDocument(
+		"firstBatch", wirebson.MustArray(),
+		"id", int64(0),
+		"ns", "testdb.testcoll",
+	)
+
+	resp := cursorResponse(cursor, "firstBatch", []string{"deprecated collection"})
+	assert.Equal(t, []string{"deprecated collection"}, resp.Get("warnings"))
+}
+
+// TestCursorResponseAcrossBatchFields checks that cursorResponse extracts the right
+// batch and cursor state from both shapes it is called with: the initial
+// aggregate/find response (batchField "firstBatch") and a subsequent getMore response
+// (batchField "nextBatch"). It does not drive a live getMore round trip itself — that
+// requires a wire handler, which is wired into Server from outside this package.
+func TestCursorResponseAcrossBatchFields(t *testing.T) {
+	t.Parallel()
+
+	firstBatchCursor := wirebson.MustDocument(
+		"firstBatch", wirebson.MustArray(wirebson.MustDocument("v", int32(1))),
+		"id", int64(42),
+		"ns", "testdb.testcoll",
+	)
+
+	first := cursorResponse(firstBatchCursor, "firstBatch", nil)
+	firstCursor := first.Get("cursor").(*wirebson.Document)
+	assert.Equal(t, "42", firstCursor.Get("id"))
+	assert.Equal(t, 1, first.Get("documents").(*wirebson.Array).Len())
+
+	nextBatchCursor := wirebson.MustDocument(
+		"nextBatch", wirebson.MustArray(wirebson.MustDocument("v", int32(2)), wirebson.MustDocument("v", int32(3))),
+		"id", int64(0),
+		"ns", "testdb.testcoll",
+	)
+
+	second := cursorResponse(nextBatchCursor, "nextBatch", nil)
+	secondCursor := second.Get("cursor").(*wirebson.Document)
+	assert.Equal(t, "0", secondCursor.Get("id"), "cursor should be exhausted after the last batch")
+	assert.Equal(t, 2, second.Get("documents").(*wirebson.Array).Len())
+}
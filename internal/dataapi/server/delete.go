@@ -0,0 +1,86 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
+	"github.com/FerretDB/FerretDB/v2/internal/util/lazyerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// Delete implements [ServerInterface].
+func (s *Server) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if s.l.Enabled(ctx, slog.LevelDebug) {
+		s.l.DebugContext(ctx, fmt.Sprintf("Request:\n%s", must.NotFail(httputil.DumpRequest(r, true))))
+	}
+
+	var req api.DeleteRequestBody
+	if err := decodeJSONRequest(r, &req); err != nil {
+		http.Error(w, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	warnings, ok := s.enforcePolicy(ctx, w, "delete", req.Database, req.Collection, nil, req.Filter)
+	if !ok {
+		return
+	}
+
+	limit := 1
+	if req.Multi {
+		limit = 0
+	}
+
+	msg, err := prepareRequest(
+		"delete", req.Collection,
+		"$db", req.Database,
+		"deletes", []any{
+			map[string]any{
+				"q":     req.Filter,
+				"limit": limit,
+			},
+		},
+	)
+	if err != nil {
+		http.Error(w, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := s.handler.Handle(ctx, msg)
+	if err != nil {
+		http.Error(w, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !resp.OK() {
+		s.writeJSONError(ctx, w, resp)
+		return
+	}
+
+	fields := []any{"n", resp.Document().Get("n")}
+	if len(warnings) > 0 {
+		fields = append(fields, "warnings", warnings)
+	}
+
+	s.writeJSONResponse(ctx, w, wirebson.MustDocument(fields...))
+}
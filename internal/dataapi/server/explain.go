@@ -0,0 +1,105 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
+	"github.com/FerretDB/FerretDB/v2/internal/util/lazyerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// Explain implements [ServerInterface].
+//
+// It wraps the wire protocol's `explain` command for the aggregate, find, and count
+// commands, letting Data API callers inspect query plans without dropping to the wire protocol.
+func (s *Server) Explain(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if s.l.Enabled(ctx, slog.LevelDebug) {
+		s.l.DebugContext(ctx, fmt.Sprintf("Request:\n%s", must.NotFail(httputil.DumpRequest(r, true))))
+	}
+
+	var req api.ExplainRequestBody
+	if err := decodeJSONRequest(r, &req); err != nil {
+		http.Error(w, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var explained *wirebson.Document
+
+	switch req.Command {
+	case "aggregate":
+		explained = wirebson.MustDocument(
+			"aggregate", req.Collection,
+			"pipeline", req.Pipeline,
+			"cursor", wirebson.MustDocument(),
+		)
+	case "find":
+		explained = wirebson.MustDocument(
+			"find", req.Collection,
+			"filter", req.Filter,
+		)
+	case "count":
+		explained = wirebson.MustDocument(
+			"count", req.Collection,
+			"query", req.Filter,
+		)
+	default:
+		http.Error(w, lazyerrors.Errorf("unsupported explain command %q", req.Command).Error(), http.StatusBadRequest)
+		return
+	}
+
+	verbosity := req.Verbosity
+	if verbosity == "" {
+		verbosity = "queryPlanner"
+	}
+
+	msg, err := prepareRequest(
+		"explain", explained,
+		"verbosity", verbosity,
+		"$db", req.Database,
+	)
+	if err != nil {
+		http.Error(w, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := s.handler.Handle(ctx, msg)
+	if err != nil {
+		http.Error(w, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !resp.OK() {
+		s.writeJSONError(ctx, w, resp)
+		return
+	}
+
+	doc := resp.Document()
+
+	fields := []any{"queryPlanner", doc.Get("queryPlanner")}
+	if stats := doc.Get("executionStats"); stats != nil {
+		fields = append(fields, "executionStats", stats)
+	}
+
+	s.writeJSONResponse(ctx, w, wirebson.MustDocument(fields...))
+}
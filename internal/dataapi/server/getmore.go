@@ -0,0 +1,83 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+
+	"github.com/FerretDB/wire/wirebson"
+
+	"github.com/FerretDB/FerretDB/v2/internal/dataapi/api"
+	"github.com/FerretDB/FerretDB/v2/internal/util/lazyerrors"
+	"github.com/FerretDB/FerretDB/v2/internal/util/must"
+)
+
+// GetMore implements [ServerInterface].
+//
+// It wraps the wire protocol's `getMore` command so that callers of Aggregate and Find
+// can retrieve batches beyond the first one returned by those endpoints.
+func (s *Server) GetMore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if s.l.Enabled(ctx, slog.LevelDebug) {
+		s.l.DebugContext(ctx, fmt.Sprintf("Request:\n%s", must.NotFail(httputil.DumpRequest(r, true))))
+	}
+
+	var req api.GetMoreRequestBody
+	if err := decodeJSONRequest(r, &req); err != nil {
+		http.Error(w, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	cursorID, err := strconv.ParseInt(req.CursorID, 10, 64)
+	if err != nil {
+		http.Error(w, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	pairs := []any{
+		"getMore", cursorID,
+		"$db", req.Database,
+		"collection", req.Collection,
+	}
+
+	if req.BatchSize != nil {
+		pairs = append(pairs, "batchSize", *req.BatchSize)
+	}
+
+	msg, err := prepareRequest(pairs...)
+	if err != nil {
+		http.Error(w, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := s.handler.Handle(ctx, msg)
+	if err != nil {
+		http.Error(w, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !resp.OK() {
+		s.writeJSONError(ctx, w, resp)
+		return
+	}
+
+	cursor := resp.Document().Get("cursor").(wirebson.AnyDocument)
+	s.writeJSONResponse(ctx, w, cursorResponse(cursor, "nextBatch", nil))
+}
@@ -0,0 +1,113 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/FerretDB/wire/wirebson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWantsNDJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		accept   []string
+		expected bool
+	}{
+		"Exact":          {accept: []string{"application/x-ndjson"}, expected: true},
+		"WithParams":     {accept: []string{"application/x-ndjson; charset=utf-8"}, expected: true},
+		"AmongMultiple":  {accept: []string{"application/json, application/x-ndjson"}, expected: true},
+		"JSONOnly":       {accept: []string{"application/json"}, expected: false},
+		"NoAcceptHeader": {accept: nil, expected: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest("POST", "/action/aggregate", nil)
+			for _, v := range tc.accept {
+				r.Header.Add("Accept", v)
+			}
+
+			assert.Equal(t, tc.expected, wantsNDJSON(r))
+		})
+	}
+}
+
+// TestStreamCursorSingleBatch exercises the Accept: application/x-ndjson path for a
+// cursor that is already exhausted after its first batch: one line per document,
+// the right content type, and no lost getMore round trip (none is needed).
+func TestStreamCursorSingleBatch(t *testing.T) {
+	t.Parallel()
+
+	s := &Server{l: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	cursor := wirebson.MustDocument(
+		"firstBatch", wirebson.MustArray(
+			wirebson.MustDocument("v", int32(1)),
+			wirebson.MustDocument("v", int32(2)),
+		),
+		"id", int64(0),
+		"ns", "testdb.testcoll",
+	)
+
+	w := httptest.NewRecorder()
+	s.streamCursor(context.Background(), w, "testdb", "testcoll", cursor, "firstBatch", nil)
+
+	assert.Equal(t, ndjsonContentType, w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Header().Get("X-Warnings"))
+
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &doc))
+	assert.InDelta(t, 1, doc["v"], 0)
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &doc))
+	assert.InDelta(t, 2, doc["v"], 0)
+}
+
+// TestStreamCursorWarningsHeader checks that policy warnings, which have no natural
+// place in a streamed body, are surfaced via the X-Warnings header instead of being
+// silently dropped.
+func TestStreamCursorWarningsHeader(t *testing.T) {
+	t.Parallel()
+
+	s := &Server{l: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	cursor := wirebson.MustDocument(
+		"firstBatch", wirebson.MustArray(),
+		"id", int64(0),
+		"ns", "testdb.testcoll",
+	)
+
+	w := httptest.NewRecorder()
+	s.streamCursor(context.Background(), w, "testdb", "testcoll", cursor, "firstBatch", []string{"deprecated collection"})
+
+	var warnings []string
+	require.NoError(t, json.Unmarshal([]byte(w.Header().Get("X-Warnings")), &warnings))
+	assert.Equal(t, []string{"deprecated collection"}, warnings)
+}
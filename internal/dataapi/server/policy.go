@@ -0,0 +1,98 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/lazyerrors"
+)
+
+// PolicyAction describes how a [PolicyResult] returned by a [PolicyEvaluator] should be enforced.
+type PolicyAction string
+
+// Policy actions.
+const (
+	// PolicyActionDeny rejects the request with an HTTP 403 response.
+	PolicyActionDeny PolicyAction = "deny"
+
+	// PolicyActionWarn lets the request through, attaching the message to the response's warnings.
+	PolicyActionWarn PolicyAction = "warn"
+
+	// PolicyActionDryRun logs that the rule matched, without affecting the request.
+	PolicyActionDryRun PolicyAction = "dryrun"
+)
+
+// PolicyResult is a single rule match produced by a [PolicyEvaluator].
+type PolicyResult struct {
+	Action  PolicyAction
+	Message string
+}
+
+// PolicyEvaluator decides how a decoded Data API command should be enforced before it
+// is dispatched to the wire protocol handler.
+//
+// Pipeline is set for aggregate commands, filter is set for find, update, and delete commands;
+// the unused one is nil.
+type PolicyEvaluator interface {
+	Evaluate(ctx context.Context, command, database, collection string, pipeline []any, filter map[string]any) ([]PolicyResult, error)
+}
+
+// enforcePolicy runs s.policy, if any, against the given command.
+//
+// It returns warnings to attach to a successful response. If ok is false, the caller must
+// stop processing the request: enforcePolicy has already written the denial response.
+func (s *Server) enforcePolicy(ctx context.Context, w http.ResponseWriter, command, database, collection string, pipeline []any, filter map[string]any) (warnings []string, ok bool) {
+	if s.policy == nil {
+		return nil, true
+	}
+
+	results, err := s.policy.Evaluate(ctx, command, database, collection, pipeline, filter)
+	if err != nil {
+		http.Error(w, lazyerrors.Error(err).Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+
+	var denials []string
+
+	for _, res := range results {
+		switch res.Action {
+		case PolicyActionDeny:
+			denials = append(denials, res.Message)
+		case PolicyActionWarn:
+			warnings = append(warnings, res.Message)
+		case PolicyActionDryRun:
+			s.l.InfoContext(ctx, "Policy dry-run match", slog.String("command", command), slog.String("message", res.Message))
+		default:
+			s.l.WarnContext(ctx, "Unknown policy action", slog.String("action", string(res.Action)))
+		}
+	}
+
+	if len(denials) == 0 {
+		return warnings, true
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+
+	if encErr := json.NewEncoder(w).Encode(map[string]any{"messages": denials}); encErr != nil {
+		s.l.ErrorContext(ctx, "Failed to write policy denial response", slog.Any("error", encErr))
+	}
+
+	return nil, false
+}
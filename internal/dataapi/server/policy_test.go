@@ -0,0 +1,298 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineUsesOperator(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		pipeline  []any
+		operators []string
+		expected  bool
+	}{
+		"OutStage": {
+			pipeline:  []any{map[string]any{"$match": map[string]any{"v": 1}}, map[string]any{"$out": "target"}},
+			operators: []string{"$out", "$merge"},
+			expected:  true,
+		},
+		"MergeStage": {
+			pipeline:  []any{map[string]any{"$merge": map[string]any{"into": "target"}}},
+			operators: []string{"$out", "$merge"},
+			expected:  true,
+		},
+		"NoMatchingStage": {
+			pipeline:  []any{map[string]any{"$match": map[string]any{"v": 1}}},
+			operators: []string{"$out", "$merge"},
+			expected:  false,
+		},
+		"EmptyPipeline": {
+			pipeline:  nil,
+			operators: []string{"$out", "$merge"},
+			expected:  false,
+		},
+		"NonDocumentStage": {
+			pipeline:  []any{1, "not a stage"},
+			operators: []string{"$out"},
+			expected:  false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, pipelineUsesOperator(tc.pipeline, tc.operators))
+		})
+	}
+}
+
+func TestYAMLPolicyEvaluatorEvaluate(t *testing.T) {
+	t.Parallel()
+
+	e := &YAMLPolicyEvaluator{
+		rules: []yamlPolicyRule{
+			{
+				Collection: "audit",
+				Operators:  []string{"$out", "$merge"},
+				Action:     PolicyActionDeny,
+				Message:    "audit may not be targeted by $out/$merge",
+			},
+			{
+				Database: "reporting",
+				Action:   PolicyActionWarn,
+				Message:  "reporting is deprecated",
+			},
+			{
+				Commands: []string{"delete"},
+				Action:   PolicyActionDryRun,
+				Message:  "delete dry-run",
+			},
+			{
+				Collection: "orders",
+				Filter:     map[string]any{"status": "archived"},
+				Action:     PolicyActionDeny,
+				Message:    "archived orders are read-only",
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		command    string
+		database   string
+		collection string
+		pipeline   []any
+		filter     map[string]any
+		expected   []PolicyResult
+	}{
+		"OutOnAuditCollectionDenied": {
+			command:    "aggregate",
+			database:   "app",
+			collection: "audit",
+			pipeline:   []any{map[string]any{"$out": "copy"}},
+			expected: []PolicyResult{
+				{Action: PolicyActionDeny, Message: "audit may not be targeted by $out/$merge"},
+			},
+		},
+		"MatchOnAuditCollectionNotDenied": {
+			command:    "aggregate",
+			database:   "app",
+			collection: "audit",
+			pipeline:   []any{map[string]any{"$match": map[string]any{"v": 1}}},
+			expected:   nil,
+		},
+		"ReportingDatabaseWarned": {
+			command:    "find",
+			database:   "reporting",
+			collection: "events",
+			expected: []PolicyResult{
+				{Action: PolicyActionWarn, Message: "reporting is deprecated"},
+			},
+		},
+		"DeleteCommandDryRun": {
+			command:    "delete",
+			database:   "app",
+			collection: "users",
+			expected: []PolicyResult{
+				{Action: PolicyActionDryRun, Message: "delete dry-run"},
+			},
+		},
+		"MultipleRulesMatch": {
+			command:    "delete",
+			database:   "reporting",
+			collection: "audit",
+			pipeline:   []any{map[string]any{"$merge": "copy"}},
+			expected: []PolicyResult{
+				{Action: PolicyActionDeny, Message: "audit may not be targeted by $out/$merge"},
+				{Action: PolicyActionWarn, Message: "reporting is deprecated"},
+				{Action: PolicyActionDryRun, Message: "delete dry-run"},
+			},
+		},
+		"FilterScopedRuleMatches": {
+			command:    "delete",
+			database:   "app",
+			collection: "orders",
+			filter:     map[string]any{"status": "archived"},
+			expected: []PolicyResult{
+				{Action: PolicyActionDeny, Message: "archived orders are read-only"},
+			},
+		},
+		"FilterScopedRuleNoMatch": {
+			command:    "delete",
+			database:   "app",
+			collection: "orders",
+			filter:     map[string]any{"status": "open"},
+			expected:   nil,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			results, err := e.Evaluate(context.Background(), tc.command, tc.database, tc.collection, tc.pipeline, tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, results)
+		})
+	}
+}
+
+func TestNewYAMLPolicyEvaluator(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := dir + "/policy.yml"
+
+	contents := `
+rules:
+  - database: reporting
+    action: warn
+    message: reporting is deprecated
+  - collection: orders
+    filter:
+      status: archived
+    action: deny
+    message: archived orders are read-only
+`
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+
+	e, err := NewYAMLPolicyEvaluator(path)
+	require.NoError(t, err)
+
+	expected := []yamlPolicyRule{
+		{Database: "reporting", Action: PolicyActionWarn, Message: "reporting is deprecated"},
+		{
+			Collection: "orders",
+			Filter:     map[string]any{"status": "archived"},
+			Action:     PolicyActionDeny,
+			Message:    "archived orders are read-only",
+		},
+	}
+	assert.Equal(t, expected, e.rules)
+
+	_, err = NewYAMLPolicyEvaluator(dir + "/missing.yml")
+	assert.Error(t, err)
+}
+
+// stubPolicyEvaluator is a [PolicyEvaluator] that always returns a fixed set of results.
+type stubPolicyEvaluator struct {
+	results []PolicyResult
+}
+
+func (e *stubPolicyEvaluator) Evaluate(context.Context, string, string, string, []any, map[string]any) ([]PolicyResult, error) {
+	return e.results, nil
+}
+
+func testServer() *Server {
+	return &Server{l: slog.New(slog.NewTextHandler(io.Discard, nil))}
+}
+
+func TestEnforcePolicyDeny(t *testing.T) {
+	t.Parallel()
+
+	s := testServer()
+	s.policy = &stubPolicyEvaluator{results: []PolicyResult{
+		{Action: PolicyActionDeny, Message: "denied by policy"},
+	}}
+
+	w := httptest.NewRecorder()
+	warnings, ok := s.enforcePolicy(context.Background(), w, "aggregate", "app", "audit", nil, nil)
+
+	assert.False(t, ok)
+	assert.Nil(t, warnings)
+	assert.Equal(t, 403, w.Code)
+
+	var body struct {
+		Messages []string `json:"messages"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, []string{"denied by policy"}, body.Messages)
+}
+
+func TestEnforcePolicyWarn(t *testing.T) {
+	t.Parallel()
+
+	s := testServer()
+	s.policy = &stubPolicyEvaluator{results: []PolicyResult{
+		{Action: PolicyActionWarn, Message: "deprecated collection"},
+	}}
+
+	w := httptest.NewRecorder()
+	warnings, ok := s.enforcePolicy(context.Background(), w, "find", "app", "audit", nil, nil)
+
+	assert.True(t, ok)
+	assert.Equal(t, []string{"deprecated collection"}, warnings)
+	assert.Equal(t, 200, w.Code)
+}
+
+func TestEnforcePolicyDryRun(t *testing.T) {
+	t.Parallel()
+
+	s := testServer()
+	s.policy = &stubPolicyEvaluator{results: []PolicyResult{
+		{Action: PolicyActionDryRun, Message: "would have been denied"},
+	}}
+
+	w := httptest.NewRecorder()
+	warnings, ok := s.enforcePolicy(context.Background(), w, "delete", "app", "audit", nil, nil)
+
+	assert.True(t, ok)
+	assert.Nil(t, warnings)
+	assert.Equal(t, 200, w.Code)
+}
+
+func TestEnforcePolicyNoEvaluator(t *testing.T) {
+	t.Parallel()
+
+	s := testServer()
+
+	w := httptest.NewRecorder()
+	warnings, ok := s.enforcePolicy(context.Background(), w, "find", "app", "audit", nil, nil)
+
+	assert.True(t, ok)
+	assert.Nil(t, warnings)
+}
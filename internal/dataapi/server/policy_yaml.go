@@ -0,0 +1,139 @@
+// Copyright 2021 FerretDB Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/FerretDB/FerretDB/v2/internal/util/lazyerrors"
+)
+
+// yamlPolicyRule is a single rule loaded from a [YAMLPolicyEvaluator] config file.
+//
+// A rule applies to a command if Database, Collection, and Commands all match (empty
+// means "any"), if, when Operators is set, the aggregate pipeline contains at least
+// one stage using one of the listed operators (e.g. "$out", "$merge"), and if, when
+// Filter is set, the command's filter (find, update, and delete only) contains every
+// key/value pair in Filter.
+type yamlPolicyRule struct {
+	Database   string         `yaml:"database"`
+	Collection string         `yaml:"collection"`
+	Commands   []string       `yaml:"commands"`
+	Operators  []string       `yaml:"operators"`
+	Filter     map[string]any `yaml:"filter"`
+	Action     PolicyAction   `yaml:"action"`
+	Message    string         `yaml:"message"`
+}
+
+// yamlPolicyConfig is the top-level shape of a [YAMLPolicyEvaluator] config file.
+type yamlPolicyConfig struct {
+	Rules []yamlPolicyRule `yaml:"rules"`
+}
+
+// YAMLPolicyEvaluator is a built-in [PolicyEvaluator] that loads rules from a YAML file,
+// letting operators restrict Data API traffic (e.g. "collection X may not be targeted by
+// pipelines containing $out/$merge", "database Y is read-only from the Data API")
+// without recompiling.
+type YAMLPolicyEvaluator struct {
+	rules []yamlPolicyRule
+}
+
+// NewYAMLPolicyEvaluator reads and parses the rules at path.
+func NewYAMLPolicyEvaluator(path string) (*YAMLPolicyEvaluator, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	var cfg yamlPolicyConfig
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return nil, lazyerrors.Error(err)
+	}
+
+	return &YAMLPolicyEvaluator{rules: cfg.Rules}, nil
+}
+
+// Evaluate implements [PolicyEvaluator].
+func (e *YAMLPolicyEvaluator) Evaluate(
+	_ context.Context,
+	command, database, collection string,
+	pipeline []any,
+	filter map[string]any,
+) ([]PolicyResult, error) {
+	var results []PolicyResult
+
+	for _, rule := range e.rules {
+		if rule.Database != "" && rule.Database != database {
+			continue
+		}
+
+		if rule.Collection != "" && rule.Collection != collection {
+			continue
+		}
+
+		if len(rule.Commands) > 0 && !slices.Contains(rule.Commands, command) {
+			continue
+		}
+
+		if len(rule.Operators) > 0 && !pipelineUsesOperator(pipeline, rule.Operators) {
+			continue
+		}
+
+		if len(rule.Filter) > 0 && !filterMatchesRule(filter, rule.Filter) {
+			continue
+		}
+
+		results = append(results, PolicyResult{Action: rule.Action, Message: rule.Message})
+	}
+
+	return results, nil
+}
+
+// filterMatchesRule reports whether filter contains every key/value pair in want,
+// letting a rule scope itself to commands whose filter targets a specific field
+// (e.g. {"status": "archived"}).
+func filterMatchesRule(filter, want map[string]any) bool {
+	for k, v := range want {
+		fv, ok := filter[k]
+		if !ok || !reflect.DeepEqual(fv, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pipelineUsesOperator reports whether any stage of pipeline uses one of operators as its stage key.
+func pipelineUsesOperator(pipeline []any, operators []string) bool {
+	for _, stage := range pipeline {
+		doc, ok := stage.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for key := range doc {
+			if slices.Contains(operators, key) {
+				return true
+			}
+		}
+	}
+
+	return false
+}